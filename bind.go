@@ -0,0 +1,95 @@
+package endpoint
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+// BindRequest populates dst, a pointer to a struct, from path parameters,
+// query string values and HTTP headers using the struct's "path", "query" and
+// "header" tags. It is intended to be called from a router-specific
+// DecodeRequestFunc after the request body, if any, has been decoded into the
+// same struct via its "json" tags.
+func BindRequest(dst any, path map[string]string, query url.Values, header http.Header) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("endpoint: BindRequest requires a pointer to a struct, got %T", dst)
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if tag, ok := field.Tag.Lookup("path"); ok {
+			if value, ok := path[tag]; ok {
+				if err := setField(v.Field(i), value); err != nil {
+					return fmt.Errorf("endpoint: binding path parameter %q: %w", tag, err)
+				}
+			}
+			continue
+		}
+
+		if tag, ok := field.Tag.Lookup("query"); ok {
+			if query.Has(tag) {
+				if err := setField(v.Field(i), query.Get(tag)); err != nil {
+					return fmt.Errorf("endpoint: binding query parameter %q: %w", tag, err)
+				}
+			}
+			continue
+		}
+
+		if tag, ok := field.Tag.Lookup("header"); ok {
+			if value := header.Get(tag); value != "" {
+				if err := setField(v.Field(i), value); err != nil {
+					return fmt.Errorf("endpoint: binding header %q: %w", tag, err)
+				}
+			}
+			continue
+		}
+	}
+
+	return nil
+}
+
+// setField converts value to field's type and assigns it. Only the scalar
+// kinds commonly found in path/query/header parameters are supported.
+func setField(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(f)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("endpoint: unsupported field kind %s", field.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package endpoint
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+type bindTestRequest struct {
+	ID     string `path:"id"`
+	Limit  int    `query:"limit"`
+	Active bool   `query:"active"`
+	Trace  string `header:"X-Trace-Id"`
+}
+
+func TestBindRequest_BindsPathQueryAndHeader(t *testing.T) {
+	var dst bindTestRequest
+
+	path := map[string]string{"id": "widget-1"}
+	query := url.Values{"limit": []string{"25"}, "active": []string{"true"}}
+	header := http.Header{"X-Trace-Id": []string{"abc-123"}}
+
+	if err := BindRequest(&dst, path, query, header); err != nil {
+		t.Fatalf("BindRequest: %v", err)
+	}
+
+	if dst.ID != "widget-1" || dst.Limit != 25 || !dst.Active || dst.Trace != "abc-123" {
+		t.Fatalf("unexpected bind result: %+v", dst)
+	}
+}
+
+func TestBindRequest_MissingValuesLeaveFieldsZero(t *testing.T) {
+	var dst bindTestRequest
+
+	if err := BindRequest(&dst, map[string]string{}, url.Values{}, http.Header{}); err != nil {
+		t.Fatalf("BindRequest: %v", err)
+	}
+
+	if dst.ID != "" || dst.Limit != 0 || dst.Active || dst.Trace != "" {
+		t.Fatalf("expected zero-value fields, got %+v", dst)
+	}
+}
+
+func TestBindRequest_InvalidQueryValueReturnsError(t *testing.T) {
+	var dst bindTestRequest
+
+	query := url.Values{"limit": []string{"not-a-number"}}
+	if err := BindRequest(&dst, map[string]string{}, query, http.Header{}); err == nil {
+		t.Fatalf("expected an error for an unparseable int field")
+	}
+}
+
+func TestBindRequest_RequiresPointerToStruct(t *testing.T) {
+	var dst bindTestRequest
+
+	if err := BindRequest(dst, map[string]string{}, url.Values{}, http.Header{}); err == nil {
+		t.Fatalf("expected an error when dst is not a pointer")
+	}
+}
@@ -45,6 +45,10 @@ type ClientHooks struct {
 	// OnError is invoked when an error is returned by the implementation of the
 	// HttpClient interface.
 	OnError func(context.Context, error)
+
+	// TraceCompleted is invoked with the populated TraceInfo once the request
+	// has completed, when tracing has been enabled via WithTracing.
+	TraceCompleted func(ctx context.Context, info TraceInfo)
 }
 
 // Client is a type for building an Endpoint invoke a remote service over HTTP.
@@ -53,6 +57,16 @@ type Client[T, R any] struct {
 	reqBuilder CreateRequestFunc[T]
 	decoder    DecodeResponseFunc[R]
 	hooks      ClientHooks
+	before     []RequestFunc
+	after      []ClientResponseFunc
+	middleware []Middleware[T, R]
+
+	// bufferedStream, when true, disables the automatic context cancellation
+	// Endpoint normally performs once it returns, so a streaming decoder (see
+	// DecodeSSEStream, DecodeNDJSONStream) can keep reading resp.Body after
+	// Endpoint has returned the stream to the caller. Set via
+	// WithBufferedStream.
+	bufferedStream bool
 }
 
 // NewClient initializes a new Client which acts as a builder for an Endpoint.
@@ -61,7 +75,7 @@ func NewClient[T, R any](
 	uri string,
 	encoder EncodeRequestFunc[T],
 	decoder DecodeResponseFunc[R],
-	opts ...ClientOptions[T, R]) *Client[T, R] {
+	opts ...ClientOption[T, R]) *Client[T, R] {
 
 	client := &Client[T, R]{
 		client:     http.DefaultClient,
@@ -83,7 +97,7 @@ func NewClient[T, R any](
 func NewCustomRequestClient[T, R any](
 	reqBuilder CreateRequestFunc[T],
 	decoder DecodeResponseFunc[R],
-	opts ...ClientOptions[T, R]) *Client[T, R] {
+	opts ...ClientOption[T, R]) *Client[T, R] {
 
 	client := &Client[T, R]{
 		client:     http.DefaultClient,
@@ -101,9 +115,12 @@ func NewCustomRequestClient[T, R any](
 
 // Endpoint returns an Endpoint that can be used to invoke the remote service.
 func (c *Client[T, R]) Endpoint() Endpoint[T, R] {
-	return func(ctx context.Context, request T) (R, error) {
-		ctx, cancel := context.WithCancel(ctx)
-		defer cancel()
+	e := func(ctx context.Context, request T) (R, error) {
+		if !c.bufferedStream {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithCancel(ctx)
+			defer cancel()
+		}
 
 		var (
 			zero       R
@@ -130,6 +147,10 @@ func (c *Client[T, R]) Endpoint() Endpoint[T, R] {
 			ctx = c.hooks.RequestPrepared(ctx, req)
 		}
 
+		for _, before := range c.before {
+			ctx = before(ctx, req)
+		}
+
 		if c.hooks.BeforeSendRequest != nil {
 			c.hooks.BeforeSendRequest(ctx)
 		}
@@ -144,6 +165,10 @@ func (c *Client[T, R]) Endpoint() Endpoint[T, R] {
 			ctx = c.hooks.ResponseReceived(ctx, resp)
 		}
 
+		for _, after := range c.after {
+			ctx = after(ctx, resp)
+		}
+
 		response, err := c.decoder(ctx, resp)
 		if err != nil {
 			err = fmt.Errorf("%w: %w", ErrDecodeResponse, err)
@@ -159,6 +184,12 @@ func (c *Client[T, R]) Endpoint() Endpoint[T, R] {
 
 		return response, nil
 	}
+
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		e = c.middleware[i](e)
+	}
+
+	return e
 }
 
 // EncodeJSONRequest is a EncodeRequestFunc that encodes the request as JSON.
@@ -0,0 +1,450 @@
+package endpoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Codec encodes and decodes request/response bodies for a single media type.
+// Built-in codecs are provided for JSON, XML, form-url-encoded, Protobuf and
+// MessagePack payloads; register additional implementations to support other
+// formats.
+type Codec interface {
+	// Marshal encodes v into the codec's wire format.
+	Marshal(v any) ([]byte, error)
+
+	// Unmarshal decodes data in the codec's wire format into v.
+	Unmarshal(data []byte, v any) error
+
+	// ContentType is the media type written to the Content-Type header when
+	// this codec encodes a payload.
+	ContentType() string
+
+	// Accept is the media type this codec is registered under for content
+	// negotiation. It is almost always equal to ContentType.
+	Accept() string
+}
+
+// acceptContextKey is the context key Server.ServeHTTP uses to make the
+// request's Accept header available to EncodeResponse without threading the
+// *http.Request through EncodeResponseFunc.
+type acceptContextKey struct{}
+
+func acceptHeaderFromContext(ctx context.Context) string {
+	accept, _ := ctx.Value(acceptContextKey{}).(string)
+	return accept
+}
+
+// CodecRegistry is a thread-safe collection of Codec implementations keyed by
+// media type, used to perform Accept/Content-Type negotiation.
+type CodecRegistry struct {
+	mu     sync.RWMutex
+	codecs map[string]Codec
+	order  []string
+}
+
+// NewCodecRegistry creates an empty CodecRegistry.
+func NewCodecRegistry() *CodecRegistry {
+	return &CodecRegistry{codecs: make(map[string]Codec)}
+}
+
+// Register adds codec to the registry, keyed by its Accept media type. Codecs
+// retains registration order so that Codecs and Negotiate are deterministic:
+// the first codec registered is preferred when a request's Accept header
+// (or the lack of one, which is treated as "*/*") matches more than one.
+func (reg *CodecRegistry) Register(codec Codec) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+	key := codec.Accept()
+	if _, exists := reg.codecs[key]; !exists {
+		reg.order = append(reg.order, key)
+	}
+	reg.codecs[key] = codec
+}
+
+// Lookup returns the Codec registered for the exact media type, if any.
+func (reg *CodecRegistry) Lookup(mediaType string) (Codec, bool) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	codec, ok := reg.codecs[mediaType]
+	return codec, ok
+}
+
+// Codecs returns a snapshot of all registered codecs, in the order they were
+// registered.
+func (reg *CodecRegistry) Codecs() []Codec {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+	out := make([]Codec, 0, len(reg.order))
+	for _, key := range reg.order {
+		out = append(out, reg.codecs[key])
+	}
+	return out
+}
+
+// Negotiate parses an Accept header and returns the best matching Codec
+// registered in reg, honouring q-values and "*/*"/"type/*" wildcards.
+func (reg *CodecRegistry) Negotiate(accept string) (Codec, bool) {
+	return negotiate(reg.Codecs(), accept)
+}
+
+// DefaultCodecRegistry is pre-populated with all of the built-in codecs:
+// JSON, XML, form-url-encoded, Protobuf and MessagePack.
+var DefaultCodecRegistry = NewCodecRegistry()
+
+func init() {
+	DefaultCodecRegistry.Register(JSONCodec{})
+	DefaultCodecRegistry.Register(XMLCodec{})
+	DefaultCodecRegistry.Register(FormCodec{})
+	DefaultCodecRegistry.Register(ProtobufCodec{})
+	DefaultCodecRegistry.Register(MessagePackCodec{})
+}
+
+// mediaParam is a single entry parsed out of an Accept header, e.g.
+// "application/json;q=0.8".
+type mediaParam struct {
+	mediaType string
+	q         float64
+}
+
+// negotiate picks the highest-priority entry in accept that matches one of
+// codecs, treating a missing Accept header as "*/*".
+func negotiate(codecs []Codec, accept string) (Codec, bool) {
+	if strings.TrimSpace(accept) == "" {
+		accept = "*/*"
+	}
+
+	var candidates []mediaParam
+	for _, part := range strings.Split(accept, ",") {
+		mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		candidates = append(candidates, mediaParam{mediaType: mt, q: q})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].q > candidates[j].q
+	})
+
+	for _, candidate := range candidates {
+		if candidate.q <= 0 {
+			continue
+		}
+		for _, codec := range codecs {
+			if mediaTypeMatches(candidate.mediaType, codec.Accept()) {
+				return codec, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
+func mediaTypeMatches(accept, codecType string) bool {
+	if accept == "*/*" {
+		return true
+	}
+	acceptType, acceptSubtype, ok := splitMediaType(accept)
+	if !ok {
+		return false
+	}
+	codecMainType, codecSubtype, ok := splitMediaType(codecType)
+	if !ok {
+		return false
+	}
+	if acceptType != codecMainType {
+		return false
+	}
+	return acceptSubtype == "*" || acceptSubtype == codecSubtype
+}
+
+func splitMediaType(mediaType string) (string, string, bool) {
+	parts := strings.SplitN(mediaType, "/", 2)
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// EncodeRequest returns an EncodeRequestFunc[T] that marshals the request
+// using codec and sets the outgoing Content-Type header accordingly.
+func EncodeRequest[T any](codec Codec) EncodeRequestFunc[T] {
+	return func(_ context.Context, r *http.Request, data T) error {
+		body, err := codec.Marshal(data)
+		if err != nil {
+			return err
+		}
+		r.Header.Set("Content-Type", codec.ContentType())
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		r.ContentLength = int64(len(body))
+		return nil
+	}
+}
+
+// DecodeResponse returns a DecodeResponseFunc[R] that selects a Codec from
+// codecs based on the response's Content-Type header and unmarshals the body
+// into R. Non-2XX responses are returned as an HttpError, mirroring
+// DecodeJSONResponse.
+func DecodeResponse[R any](codecs ...Codec) DecodeResponseFunc[R] {
+	return func(_ context.Context, resp *http.Response) (R, error) {
+		defer resp.Body.Close()
+		var zero R
+
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			body, _ := io.ReadAll(resp.Body)
+			return zero, HttpError{Status: resp.StatusCode, Header: resp.Header, Body: body}
+		}
+
+		contentType := resp.Header.Get("Content-Type")
+		codec, ok := negotiate(codecs, contentType)
+		if !ok {
+			return zero, fmt.Errorf("%w: unsupported content type %q", ErrDecodeResponse, contentType)
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return zero, err
+		}
+
+		var data R
+		if err := codec.Unmarshal(body, &data); err != nil {
+			return zero, err
+		}
+		return data, nil
+	}
+}
+
+// EncodeResponse returns an EncodeResponseFunc[R] that selects a Codec from
+// codecs based on the incoming request's Accept header (made available
+// automatically by Server.ServeHTTP) and writes the marshalled response. If no
+// codec in codecs satisfies the Accept header, ErrNotAcceptable is returned so
+// the configured ErrorHandler can respond with 406 Not Acceptable.
+func EncodeResponse[R any](codecs ...Codec) EncodeResponseFunc[R] {
+	return func(ctx context.Context, w http.ResponseWriter, data R) error {
+		accept := acceptHeaderFromContext(ctx)
+		codec, ok := negotiate(codecs, accept)
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrNotAcceptable, accept)
+		}
+
+		body, err := codec.Marshal(data)
+		if err != nil {
+			return err
+		}
+
+		w.Header().Set("Content-Type", codec.ContentType())
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write(body)
+		return err
+	}
+}
+
+// DecodeRequest returns a DecodeRequestFunc[T] that selects a Codec from
+// codecs based on the incoming request's Content-Type header and unmarshals
+// the request body into T. If no codec matches, ErrUnsupportedMediaType is
+// returned so the configured ErrorHandler can respond with 415 Unsupported
+// Media Type.
+func DecodeRequest[T any](codecs ...Codec) DecodeRequestFunc[T] {
+	return func(_ context.Context, r *http.Request) (T, error) {
+		var zero T
+
+		contentType := r.Header.Get("Content-Type")
+		mt := contentType
+		if parsed, _, err := mime.ParseMediaType(contentType); err == nil {
+			mt = parsed
+		}
+
+		var codec Codec
+		for _, c := range codecs {
+			if c.Accept() == mt {
+				codec = c
+				break
+			}
+		}
+		if codec == nil {
+			return zero, fmt.Errorf("%w: %q", ErrUnsupportedMediaType, contentType)
+		}
+
+		defer r.Body.Close()
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return zero, err
+		}
+
+		var data T
+		if err := codec.Unmarshal(body, &data); err != nil {
+			return zero, err
+		}
+		return data, nil
+	}
+}
+
+// JSONCodec is a Codec implementation backed by encoding/json.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (JSONCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+func (JSONCodec) ContentType() string                { return "application/json" }
+func (JSONCodec) Accept() string                     { return "application/json" }
+
+// XMLCodec is a Codec implementation backed by encoding/xml.
+type XMLCodec struct{}
+
+func (XMLCodec) Marshal(v any) ([]byte, error)      { return xml.Marshal(v) }
+func (XMLCodec) Unmarshal(data []byte, v any) error { return xml.Unmarshal(data, v) }
+func (XMLCodec) ContentType() string                { return "application/xml" }
+func (XMLCodec) Accept() string                     { return "application/xml" }
+
+// FormCodec is a Codec implementation for application/x-www-form-urlencoded
+// payloads. It supports url.Values directly, and otherwise marshals v to a
+// flat set of string fields via encoding/json, so it is best suited to simple,
+// single-level request types.
+type FormCodec struct{}
+
+func (FormCodec) Marshal(v any) ([]byte, error) {
+	if values, ok := v.(url.Values); ok {
+		return []byte(values.Encode()), nil
+	}
+
+	fields, err := toStringMap(v)
+	if err != nil {
+		return nil, err
+	}
+
+	values := url.Values{}
+	for k, v := range fields {
+		values.Set(k, v)
+	}
+	return []byte(values.Encode()), nil
+}
+
+func (FormCodec) Unmarshal(data []byte, v any) error {
+	values, err := url.ParseQuery(string(data))
+	if err != nil {
+		return err
+	}
+
+	if dst, ok := v.(*url.Values); ok {
+		*dst = values
+		return nil
+	}
+
+	fields := make(map[string]string, len(values))
+	for k := range values {
+		fields[k] = values.Get(k)
+	}
+	return fromStringMap(fields, v)
+}
+
+func (FormCodec) ContentType() string { return "application/x-www-form-urlencoded" }
+func (FormCodec) Accept() string      { return "application/x-www-form-urlencoded" }
+
+// ProtobufCodec is a Codec implementation for protocol buffer messages, backed
+// by google.golang.org/protobuf. The value passed to Marshal, and the value
+// pointed to by Unmarshal's v, must implement proto.Message - typically a
+// pointer to a generated message type.
+type ProtobufCodec struct{}
+
+func (ProtobufCodec) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("endpoint: ProtobufCodec requires a proto.Message, got %T", v)
+	}
+	return proto.Marshal(msg)
+}
+
+func (ProtobufCodec) Unmarshal(data []byte, v any) error {
+	msg, ok := asProtoMessage(v)
+	if !ok {
+		return fmt.Errorf("endpoint: ProtobufCodec requires a proto.Message, got %T", v)
+	}
+	return proto.Unmarshal(data, msg)
+}
+
+func (ProtobufCodec) ContentType() string { return "application/x-protobuf" }
+func (ProtobufCodec) Accept() string      { return "application/x-protobuf" }
+
+// asProtoMessage extracts a proto.Message from v. Generated message types
+// implement proto.Message on their pointer type, so DecodeRequest/DecodeResponse
+// calling Unmarshal(data, &value) for a response type R that is itself such a
+// pointer end up passing a pointer-to-pointer; asProtoMessage allocates the
+// underlying message on demand and unwraps that extra level of indirection.
+func asProtoMessage(v any) (proto.Message, bool) {
+	if msg, ok := v.(proto.Message); ok {
+		return msg, true
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Ptr {
+		return nil, false
+	}
+
+	elem := rv.Elem()
+	if elem.IsNil() {
+		elem.Set(reflect.New(elem.Type().Elem()))
+	}
+
+	msg, ok := elem.Interface().(proto.Message)
+	return msg, ok
+}
+
+// MessagePackCodec is a Codec implementation backed by
+// github.com/vmihailenco/msgpack.
+type MessagePackCodec struct{}
+
+func (MessagePackCodec) Marshal(v any) ([]byte, error)      { return msgpack.Marshal(v) }
+func (MessagePackCodec) Unmarshal(data []byte, v any) error { return msgpack.Unmarshal(data, v) }
+func (MessagePackCodec) ContentType() string                { return "application/msgpack" }
+func (MessagePackCodec) Accept() string                     { return "application/msgpack" }
+
+// toStringMap flattens v's top-level JSON fields into a map of strings,
+// suitable for encoding as application/x-www-form-urlencoded.
+func toStringMap(v any) (map[string]string, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var loose map[string]any
+	if err := json.Unmarshal(raw, &loose); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]string, len(loose))
+	for k, val := range loose {
+		out[k] = fmt.Sprintf("%v", val)
+	}
+	return out, nil
+}
+
+// fromStringMap is the inverse of toStringMap: it round-trips fields through
+// encoding/json so that v's own `json` struct tags are honoured.
+func fromStringMap(fields map[string]string, v any) error {
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, v)
+}
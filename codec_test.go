@@ -0,0 +1,158 @@
+package endpoint
+
+import "testing"
+
+type codecTestPayload struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+func TestNegotiate_PicksHighestQValue(t *testing.T) {
+	codecs := []Codec{JSONCodec{}, XMLCodec{}}
+
+	codec, ok := negotiate(codecs, "application/xml;q=0.9, application/json;q=0.5")
+	if !ok {
+		t.Fatalf("expected a match")
+	}
+	if codec.Accept() != "application/xml" {
+		t.Fatalf("expected application/xml to win on q-value, got %s", codec.Accept())
+	}
+}
+
+func TestNegotiate_WildcardMatchesFirstAvailable(t *testing.T) {
+	codecs := []Codec{JSONCodec{}}
+
+	codec, ok := negotiate(codecs, "*/*")
+	if !ok || codec.Accept() != "application/json" {
+		t.Fatalf("expected */* to match application/json, got %v, ok=%v", codec, ok)
+	}
+
+	codec, ok = negotiate(codecs, "")
+	if !ok || codec.Accept() != "application/json" {
+		t.Fatalf("expected empty Accept header to match application/json, got %v, ok=%v", codec, ok)
+	}
+}
+
+func TestNegotiate_NoMatchReturnsFalse(t *testing.T) {
+	codecs := []Codec{JSONCodec{}}
+
+	if _, ok := negotiate(codecs, "application/xml"); ok {
+		t.Fatalf("expected no match for application/xml against a JSON-only codec list")
+	}
+}
+
+func TestCodecRegistry_RegisterAndLookup(t *testing.T) {
+	reg := NewCodecRegistry()
+	reg.Register(JSONCodec{})
+
+	codec, ok := reg.Lookup("application/json")
+	if !ok || codec.ContentType() != "application/json" {
+		t.Fatalf("expected to find the registered JSON codec")
+	}
+
+	if _, ok := reg.Lookup("application/xml"); ok {
+		t.Fatalf("did not expect to find an unregistered media type")
+	}
+
+	codec, ok = reg.Negotiate("application/json")
+	if !ok || codec.ContentType() != "application/json" {
+		t.Fatalf("expected Negotiate to find the registered JSON codec")
+	}
+}
+
+func TestCodecRegistry_CodecsIsRegistrationOrderAndStable(t *testing.T) {
+	reg := NewCodecRegistry()
+	reg.Register(XMLCodec{})
+	reg.Register(JSONCodec{})
+	reg.Register(FormCodec{})
+
+	want := []string{"application/xml", "application/json", "application/x-www-form-urlencoded"}
+	for i := 0; i < 10; i++ {
+		codecs := reg.Codecs()
+		if len(codecs) != len(want) {
+			t.Fatalf("expected %d codecs, got %d", len(want), len(codecs))
+		}
+		for j, codec := range codecs {
+			if codec.Accept() != want[j] {
+				t.Fatalf("Codecs() order is not deterministic: call %d got %v", i, codecsAccepts(codecs))
+			}
+		}
+	}
+}
+
+func codecsAccepts(codecs []Codec) []string {
+	out := make([]string, len(codecs))
+	for i, c := range codecs {
+		out[i] = c.Accept()
+	}
+	return out
+}
+
+func TestDefaultCodecRegistry_NoAcceptHeaderIsDeterministic(t *testing.T) {
+	first, ok := DefaultCodecRegistry.Negotiate("")
+	if !ok {
+		t.Fatalf("expected a match for an empty Accept header")
+	}
+	for i := 0; i < 50; i++ {
+		codec, ok := DefaultCodecRegistry.Negotiate("")
+		if !ok || codec.Accept() != first.Accept() {
+			t.Fatalf("expected every call to negotiate the same codec for a missing Accept header, got %v on iteration %d", codec, i)
+		}
+	}
+}
+
+func TestJSONCodec_RoundTrip(t *testing.T) {
+	codec := JSONCodec{}
+
+	data, err := codec.Marshal(codecTestPayload{Name: "ada", Age: 36})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var out codecTestPayload
+	if err := codec.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.Name != "ada" || out.Age != 36 {
+		t.Fatalf("unexpected round-trip result: %+v", out)
+	}
+}
+
+type formTestPayload struct {
+	Name string `json:"name"`
+	Age  string `json:"age"`
+}
+
+func TestFormCodec_RoundTrip(t *testing.T) {
+	codec := FormCodec{}
+
+	data, err := codec.Marshal(formTestPayload{Name: "grace", Age: "85"})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var out formTestPayload
+	if err := codec.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.Name != "grace" || out.Age != "85" {
+		t.Fatalf("unexpected round-trip result: %+v", out)
+	}
+}
+
+func TestMessagePackCodec_RoundTrip(t *testing.T) {
+	codec := MessagePackCodec{}
+
+	data, err := codec.Marshal(codecTestPayload{Name: "alan", Age: 41})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+
+	var out codecTestPayload
+	if err := codec.Unmarshal(data, &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.Name != "alan" || out.Age != 41 {
+		t.Fatalf("unexpected round-trip result: %+v", out)
+	}
+}
@@ -0,0 +1,35 @@
+// Package endpointchi wires an *endpoint.Server[T, R] up to a go-chi/chi
+// router, e.g. router.Get("/widgets/{id}", endpointchi.NewHandler(server)).
+// chi stores its route context directly on the *http.Request it dispatches
+// with, so PathParams can be called from a DecodeRequestFunc with the
+// request it's handed, with no bridging required.
+package endpointchi
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/jkratz55/endpoint-go"
+)
+
+// PathParams returns the matched route's path parameters as a map keyed by
+// parameter name, suitable for endpoint.BindRequest.
+func PathParams(r *http.Request) map[string]string {
+	rctx := chi.RouteContext(r.Context())
+	if rctx == nil {
+		return map[string]string{}
+	}
+	params := make(map[string]string, len(rctx.URLParams.Keys))
+	for i, key := range rctx.URLParams.Keys {
+		params[key] = rctx.URLParams.Values[i]
+	}
+	return params
+}
+
+// NewHandler adapts server into an http.HandlerFunc for use with chi's
+// router. Since chi needs nothing beyond the standard
+// http.Handler interface, and its route context already travels on the
+// request, this is a direct delegation to server.ServeHTTP.
+func NewHandler[T, R any](server *endpoint.Server[T, R]) http.HandlerFunc {
+	return server.ServeHTTP
+}
@@ -0,0 +1,44 @@
+// Package endpointecho wires an *endpoint.Server[T, R] up to a
+// labstack/echo router, e.g. e.GET("/widgets/:id", endpointecho.NewHandler(server)).
+// echo dispatches handlers through its own echo.Context rather than
+// http.Handler, and keeps matched route parameters there instead of on the
+// underlying *http.Request, so NewHandler copies them into the request's
+// context for PathParams to recover.
+package endpointecho
+
+import (
+	"context"
+
+	"github.com/jkratz55/endpoint-go"
+	"github.com/labstack/echo/v4"
+)
+
+// pathParamsContextKey is the context key NewHandler uses to make echo's
+// matched path parameters available to PathParams.
+type pathParamsContextKey struct{}
+
+// PathParams returns the matched route's path parameters stashed in ctx by
+// NewHandler, as a map keyed by parameter name, suitable for
+// endpoint.BindRequest.
+func PathParams(ctx context.Context) map[string]string {
+	params, _ := ctx.Value(pathParamsContextKey{}).(map[string]string)
+	return params
+}
+
+// NewHandler adapts server into an echo.HandlerFunc for use with echo's
+// router, bridging the echo.Context it's called with into the
+// *http.Request/http.ResponseWriter pair server.ServeHTTP expects.
+func NewHandler[T, R any](server *endpoint.Server[T, R]) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		names := c.ParamNames()
+		params := make(map[string]string, len(names))
+		for _, name := range names {
+			params[name] = c.Param(name)
+		}
+
+		r := c.Request()
+		ctx := context.WithValue(r.Context(), pathParamsContextKey{}, params)
+		server.ServeHTTP(c.Response(), r.WithContext(ctx))
+		return nil
+	}
+}
@@ -0,0 +1,41 @@
+// Package endpointgin wires an *endpoint.Server[T, R] up to a gin-gonic/gin
+// router, e.g. router.GET("/widgets/:id", endpointgin.NewHandler(server)).
+// gin dispatches handlers through its own *gin.Context and keeps matched
+// route parameters there instead of on the underlying *http.Request, so
+// NewHandler copies them into the request's context for PathParams to
+// recover.
+package endpointgin
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jkratz55/endpoint-go"
+)
+
+// pathParamsContextKey is the context key NewHandler uses to make gin's
+// matched path parameters available to PathParams.
+type pathParamsContextKey struct{}
+
+// PathParams returns the matched route's path parameters stashed in ctx by
+// NewHandler, as a map keyed by parameter name, suitable for
+// endpoint.BindRequest.
+func PathParams(ctx context.Context) map[string]string {
+	params, _ := ctx.Value(pathParamsContextKey{}).(map[string]string)
+	return params
+}
+
+// NewHandler adapts server into a gin.HandlerFunc for use with gin's router,
+// bridging the *gin.Context it's called with into the
+// *http.Request/http.ResponseWriter pair server.ServeHTTP expects.
+func NewHandler[T, R any](server *endpoint.Server[T, R]) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		params := make(map[string]string, len(c.Params))
+		for _, p := range c.Params {
+			params[p.Key] = p.Value
+		}
+
+		ctx := context.WithValue(c.Request.Context(), pathParamsContextKey{}, params)
+		server.ServeHTTP(c.Writer, c.Request.WithContext(ctx))
+	}
+}
@@ -0,0 +1,63 @@
+package endpointgin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	endpoint "github.com/jkratz55/endpoint-go"
+	"github.com/jkratz55/endpoint-go/internal/adaptertest"
+)
+
+type widget struct {
+	ID   string `json:"id" path:"id"`
+	Name string `json:"name"`
+}
+
+func newWidgetRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	ep := func(ctx context.Context, req widget) (widget, error) {
+		return req, nil
+	}
+
+	decode := func(ctx context.Context, r *http.Request) (widget, error) {
+		req := widget{Name: "gizmo"}
+		if err := endpoint.BindRequest(&req, PathParams(ctx), r.URL.Query(), r.Header); err != nil {
+			return widget{}, err
+		}
+		return req, nil
+	}
+
+	server := endpoint.NewServer[widget, widget](
+		ep,
+		decode,
+		endpoint.EncodeResponse[widget](endpoint.JSONCodec{}, endpoint.XMLCodec{}),
+	)
+
+	router := gin.New()
+	router.GET("/widgets/:id", NewHandler(server))
+	return router
+}
+
+func TestNewHandler_BindsGinPathParams(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/widgets/widget-1", nil)
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+	newWidgetRouter().ServeHTTP(rec, req)
+
+	var got widget
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if got.ID != "widget-1" || got.Name != "gizmo" {
+		t.Fatalf("expected gin's :id route param to be bound, got %+v", got)
+	}
+}
+
+func TestNewHandler_NegotiatesAcceptHeader(t *testing.T) {
+	adaptertest.AssertAcceptNegotiation(t, newWidgetRouter(), "/widgets/widget-1", "application/json", "application/xml")
+}
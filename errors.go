@@ -11,6 +11,14 @@ var (
 	ErrEncodeRequest  = errors.New("encode request")
 	ErrEncodeResponse = errors.New("encode response")
 	ErrDecodeResponse = errors.New("decode response")
+
+	// ErrUnsupportedMediaType indicates the request's Content-Type did not
+	// match any Codec registered with DecodeRequest.
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+
+	// ErrNotAcceptable indicates none of the Codec registered with
+	// EncodeResponse satisfied the request's Accept header.
+	ErrNotAcceptable = errors.New("not acceptable")
 )
 
 type ErrorResponse struct {
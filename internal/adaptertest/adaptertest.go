@@ -0,0 +1,28 @@
+// Package adaptertest holds assertions shared by the endpointchi, endpointecho
+// and endpointgin adapter test suites, so each only has to wire up its own
+// router and *endpoint.Server rather than duplicate the negotiation checks.
+package adaptertest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// AssertAcceptNegotiation drives a GET request at path through handler once
+// per accept type in wantAccept, setting the Accept header to each in turn,
+// and fails t if the response Content-Type doesn't match what was asked for.
+func AssertAcceptNegotiation(t *testing.T, handler http.Handler, path string, wantAccept ...string) {
+	t.Helper()
+
+	for _, accept := range wantAccept {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept", accept)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		if ct := rec.Header().Get("Content-Type"); ct != accept {
+			t.Fatalf("Accept: %s: expected Content-Type %q, got %q", accept, accept, ct)
+		}
+	}
+}
@@ -4,3 +4,18 @@ package endpoint
 // Middleware wraps an endpoint allowing for additional processing before and/or
 // after the endpoint is invoked.
 type Middleware[T, R any] func(Endpoint[T, R]) Endpoint[T, R]
+
+// Chain composes a sequence of Middleware into a single Middleware. The
+// outer Middleware is applied first, wrapping the result of applying each of
+// middleware in order, which in turn wraps the Endpoint passed to the
+// returned Middleware.
+//
+// Chain(outer, a, b, c)(e) is equivalent to outer(a(b(c(e)))).
+func Chain[T, R any](outer Middleware[T, R], middleware ...Middleware[T, R]) Middleware[T, R] {
+	return func(next Endpoint[T, R]) Endpoint[T, R] {
+		for i := len(middleware) - 1; i >= 0; i-- {
+			next = middleware[i](next)
+		}
+		return outer(next)
+	}
+}
@@ -0,0 +1,163 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	endpoint "github.com/jkratz55/endpoint-go"
+)
+
+// ErrCircuitOpen is returned when the circuit breaker is open and rejecting
+// calls without invoking the wrapped Endpoint.
+var ErrCircuitOpen = errors.New("middleware: circuit breaker open")
+
+type breakerState int
+
+const (
+	closed breakerState = iota
+	open
+	halfOpen
+)
+
+// BreakerConfig controls CircuitBreaker.
+type BreakerConfig struct {
+
+	// FailureThreshold is the number of consecutive failures that trip the
+	// breaker from closed to open. Defaults to 5.
+	FailureThreshold int
+
+	// OpenTimeout is how long the breaker stays open before allowing a single
+	// trial call through in the half-open state. Defaults to 30s.
+	OpenTimeout time.Duration
+
+	// IsFailure reports whether err should count as a failure. Defaults to
+	// treating HTTP 5xx responses (via endpoint.HttpError) and any error that
+	// is not an endpoint.HttpError as a failure; an endpoint.HttpError with a
+	// status below 500 does not count.
+	IsFailure func(err error) bool
+
+	// OnStateChange, if set, is invoked whenever the breaker transitions
+	// between closed, open and half-open. It is intended to be wired to
+	// endpoint.ClientHooks.OnError or a tracing sink so breaker trips are
+	// visible in telemetry.
+	OnStateChange func(ctx context.Context, from, to string)
+}
+
+func (s breakerState) String() string {
+	switch s {
+	case open:
+		return "open"
+	case halfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+// CircuitBreaker returns a Middleware implementing a sony/gobreaker-style
+// circuit breaker: consecutive failures trip the breaker to open, after which
+// calls fail fast with ErrCircuitOpen until OpenTimeout elapses, at which
+// point a single half-open trial call is allowed through to decide whether to
+// close the breaker again or re-open it.
+func CircuitBreaker[T, R any](cfg BreakerConfig) endpoint.Middleware[T, R] {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = 5
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = 30 * time.Second
+	}
+	isFailure := cfg.IsFailure
+	if isFailure == nil {
+		isFailure = defaultShouldRetry
+	}
+
+	b := &breaker{cfg: cfg, isFailure: isFailure}
+
+	return func(next endpoint.Endpoint[T, R]) endpoint.Endpoint[T, R] {
+		return func(ctx context.Context, request T) (R, error) {
+			var zero R
+
+			if !b.allow(ctx) {
+				return zero, ErrCircuitOpen
+			}
+
+			resp, err := next(ctx, request)
+			b.record(ctx, err)
+			return resp, err
+		}
+	}
+}
+
+// breaker holds the shared state for a single CircuitBreaker-wrapped
+// Endpoint. It is safe for concurrent use.
+type breaker struct {
+	mu               sync.Mutex
+	cfg              BreakerConfig
+	isFailure        func(error) bool
+	state            breakerState
+	consecutiveFails int
+	openedAt         time.Time
+}
+
+// allow reports whether a call may proceed. Once OpenTimeout has elapsed on
+// an open breaker, the first caller to observe it transitions the breaker to
+// halfOpen and is let through as the single trial call; every other caller,
+// including concurrent ones, is rejected while the breaker is halfOpen.
+func (b *breaker) allow(ctx context.Context) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case open:
+		if time.Since(b.openedAt) < b.cfg.OpenTimeout {
+			return false
+		}
+		b.transition(ctx, halfOpen)
+		return true
+	case halfOpen:
+		return false
+	default:
+		return true
+	}
+}
+
+func (b *breaker) record(ctx context.Context, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	failed := err != nil && b.isFailure(err)
+
+	if b.state == halfOpen {
+		b.consecutiveFails = 0
+		if failed {
+			b.openedAt = time.Now()
+			b.transition(ctx, open)
+		} else {
+			b.transition(ctx, closed)
+		}
+		return
+	}
+
+	if !failed {
+		b.consecutiveFails = 0
+		return
+	}
+
+	b.consecutiveFails++
+	if b.consecutiveFails >= b.cfg.FailureThreshold {
+		b.openedAt = time.Now()
+		b.transition(ctx, open)
+	}
+}
+
+// transition moves the breaker to state, invoking cfg.OnStateChange if set.
+// Callers must hold b.mu.
+func (b *breaker) transition(ctx context.Context, state breakerState) {
+	from := b.state
+	b.state = state
+	if from != state && b.cfg.OnStateChange != nil {
+		b.cfg.OnStateChange(ctx, from.String(), state.String())
+	}
+}
@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	endpoint "github.com/jkratz55/endpoint-go"
+)
+
+func TestCircuitBreaker_TripsAfterThreshold(t *testing.T) {
+	failing := func(ctx context.Context, req string) (string, error) {
+		return "", errors.New("boom")
+	}
+
+	ep := CircuitBreaker[string, string](BreakerConfig{FailureThreshold: 2})(failing)
+
+	if _, err := ep(context.Background(), "a"); err == nil {
+		t.Fatalf("expected underlying error on first call")
+	}
+	if _, err := ep(context.Background(), "a"); err == nil {
+		t.Fatalf("expected underlying error on second call")
+	}
+
+	_, err := ep(context.Background(), "a")
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("expected ErrCircuitOpen after threshold failures, got %v", err)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenAllowsSingleTrial(t *testing.T) {
+	var inFlight int32
+	var maxInFlight int32
+
+	next := func(ctx context.Context, req string) (string, error) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			cur := atomic.LoadInt32(&maxInFlight)
+			if n <= cur || atomic.CompareAndSwapInt32(&maxInFlight, cur, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		return "ok", nil
+	}
+
+	b := &breaker{
+		cfg:       BreakerConfig{FailureThreshold: 1, OpenTimeout: 10 * time.Millisecond},
+		isFailure: defaultShouldRetry,
+		state:     open,
+		openedAt:  time.Now().Add(-time.Hour), // already past OpenTimeout
+	}
+
+	ep := func(next endpoint.Endpoint[string, string]) endpoint.Endpoint[string, string] {
+		return func(ctx context.Context, request string) (string, error) {
+			if !b.allow(ctx) {
+				return "", ErrCircuitOpen
+			}
+			resp, err := next(ctx, request)
+			b.record(ctx, err)
+			return resp, err
+		}
+	}(next)
+
+	const callers = 10
+	var wg sync.WaitGroup
+	var rejected int32
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			if _, err := ep(context.Background(), "x"); errors.Is(err, ErrCircuitOpen) {
+				atomic.AddInt32(&rejected, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if atomic.LoadInt32(&maxInFlight) != 1 {
+		t.Fatalf("expected exactly 1 concurrent trial call, saw %d", maxInFlight)
+	}
+	if rejected != callers-1 {
+		t.Fatalf("expected %d rejected callers, got %d", callers-1, rejected)
+	}
+}
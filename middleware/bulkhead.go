@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"context"
+
+	endpoint "github.com/jkratz55/endpoint-go"
+)
+
+// Bulkhead returns a Middleware that limits the number of concurrent in-flight
+// calls to maxConcurrent. Callers beyond the limit block until a slot frees up
+// or ctx is cancelled, isolating the wrapped Endpoint from being overwhelmed
+// by a burst of concurrent requests.
+func Bulkhead[T, R any](maxConcurrent int) endpoint.Middleware[T, R] {
+	sem := make(chan struct{}, maxConcurrent)
+
+	return func(next endpoint.Endpoint[T, R]) endpoint.Endpoint[T, R] {
+		return func(ctx context.Context, request T) (R, error) {
+			var zero R
+
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return zero, ctx.Err()
+			}
+			defer func() { <-sem }()
+
+			return next(ctx, request)
+		}
+	}
+}
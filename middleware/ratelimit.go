@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+
+	endpoint "github.com/jkratz55/endpoint-go"
+)
+
+// RateLimit returns a Middleware that waits on limiter before invoking the
+// wrapped Endpoint, blocking the caller until a token is available or ctx is
+// cancelled, in which case ctx's error is returned without invoking next.
+func RateLimit[T, R any](limiter *rate.Limiter) endpoint.Middleware[T, R] {
+	return func(next endpoint.Endpoint[T, R]) endpoint.Endpoint[T, R] {
+		return func(ctx context.Context, request T) (R, error) {
+			var zero R
+			if err := limiter.Wait(ctx); err != nil {
+				return zero, err
+			}
+			return next(ctx, request)
+		}
+	}
+}
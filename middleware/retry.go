@@ -0,0 +1,137 @@
+// Package middleware provides standard endpoint.Middleware implementations
+// for wrapping client Endpoints: retry with backoff, a circuit breaker, a
+// token-bucket rate limiter and a bulkhead concurrency limiter. They compose
+// via endpoint.Chain, e.g.:
+//
+//	ep := endpoint.Chain(
+//		middleware.CircuitBreaker[Req, Resp](breakerCfg),
+//		middleware.Retry[Req, Resp](retryCfg),
+//		middleware.RateLimit[Req, Resp](limiter),
+//	)(client.Endpoint())
+package middleware
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"time"
+
+	endpoint "github.com/jkratz55/endpoint-go"
+)
+
+// RetryConfig controls the behavior of Retry.
+type RetryConfig struct {
+
+	// MaxAttempts is the maximum number of times the Endpoint will be
+	// invoked, including the first attempt. Defaults to 1 (no retries) if
+	// unset.
+	MaxAttempts int
+
+	// BaseDelay is the backoff before the first retry, doubled for each
+	// subsequent retry. Jitter picks the actual delay between half of that
+	// backoff and the full backoff. Defaults to 100ms.
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff delay between attempts. Defaults to 2s.
+	MaxDelay time.Duration
+
+	// Method is the HTTP method of the wrapped Endpoint. It is used to decide
+	// whether a failed call is safe to retry when Idempotent is false.
+	Method string
+
+	// Idempotent forces retries even when Method is not inherently safe
+	// (anything other than GET/HEAD/OPTIONS). Leave false unless the wrapped
+	// Endpoint is known to be safe to retry regardless of method.
+	Idempotent bool
+
+	// ShouldRetry overrides the default retry decision for a given error. If
+	// nil, HTTP 5xx responses (via endpoint.HttpError) and any error that is
+	// not an endpoint.HttpError are retried.
+	ShouldRetry func(err error) bool
+
+	// OnRetry, if set, is invoked before each retry attempt. It is intended to
+	// be wired to endpoint.ClientHooks.OnError or a tracing sink so retries
+	// are visible in telemetry.
+	OnRetry func(ctx context.Context, attempt int, err error)
+}
+
+// Retry returns a Middleware that retries a failing Endpoint with exponential
+// backoff and jitter. Retries only happen for safe HTTP methods unless
+// cfg.Idempotent is set, since retrying a non-idempotent call can duplicate
+// its side effects.
+func Retry[T, R any](cfg RetryConfig) endpoint.Middleware[T, R] {
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 1
+	}
+	if cfg.BaseDelay <= 0 {
+		cfg.BaseDelay = 100 * time.Millisecond
+	}
+	if cfg.MaxDelay <= 0 {
+		cfg.MaxDelay = 2 * time.Second
+	}
+	shouldRetry := cfg.ShouldRetry
+	if shouldRetry == nil {
+		shouldRetry = defaultShouldRetry
+	}
+	canRetry := cfg.Idempotent || isSafeMethod(cfg.Method)
+
+	return func(next endpoint.Endpoint[T, R]) endpoint.Endpoint[T, R] {
+		return func(ctx context.Context, request T) (R, error) {
+			var (
+				resp R
+				err  error
+			)
+
+			for attempt := 0; attempt < cfg.MaxAttempts; attempt++ {
+				resp, err = next(ctx, request)
+				if err == nil {
+					return resp, nil
+				}
+				if !canRetry || attempt == cfg.MaxAttempts-1 || !shouldRetry(err) {
+					return resp, err
+				}
+
+				if cfg.OnRetry != nil {
+					cfg.OnRetry(ctx, attempt+1, err)
+				}
+
+				timer := time.NewTimer(backoff(cfg.BaseDelay, cfg.MaxDelay, attempt))
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return resp, ctx.Err()
+				case <-timer.C:
+				}
+			}
+
+			return resp, err
+		}
+	}
+}
+
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+func defaultShouldRetry(err error) bool {
+	var httpErr endpoint.HttpError
+	if errors.As(err, &httpErr) {
+		return httpErr.Status >= 500
+	}
+	return true
+}
+
+func backoff(base, max time.Duration, attempt int) time.Duration {
+	d := time.Duration(float64(base) * math.Pow(2, float64(attempt)))
+	if d > max {
+		d = max
+	}
+	return d/2 + time.Duration(rand.Int63n(int64(d)/2+1))
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	endpoint "github.com/jkratz55/endpoint-go"
+)
+
+func TestRetry_RetriesUpToMaxAttempts(t *testing.T) {
+	attempts := 0
+	failTwice := func(ctx context.Context, req string) (string, error) {
+		attempts++
+		if attempts < 3 {
+			return "", endpoint.HttpError{Status: http.StatusServiceUnavailable}
+		}
+		return "ok", nil
+	}
+
+	ep := Retry[string, string](RetryConfig{
+		MaxAttempts: 3,
+		BaseDelay:   time.Millisecond,
+		MaxDelay:    time.Millisecond,
+		Idempotent:  true,
+	})(failTwice)
+
+	resp, err := ep(context.Background(), "req")
+	if err != nil {
+		t.Fatalf("expected eventual success, got error: %v", err)
+	}
+	if resp != "ok" {
+		t.Fatalf("expected %q, got %q", "ok", resp)
+	}
+	if attempts != 3 {
+		t.Fatalf("expected 3 attempts, got %d", attempts)
+	}
+}
+
+func TestRetry_DoesNotRetryUnsafeMethodByDefault(t *testing.T) {
+	attempts := 0
+	alwaysFail := func(ctx context.Context, req string) (string, error) {
+		attempts++
+		return "", endpoint.HttpError{Status: http.StatusServiceUnavailable}
+	}
+
+	ep := Retry[string, string](RetryConfig{
+		MaxAttempts: 3,
+		Method:      http.MethodPost,
+	})(alwaysFail)
+
+	if _, err := ep(context.Background(), "req"); err == nil {
+		t.Fatalf("expected error")
+	}
+	if attempts != 1 {
+		t.Fatalf("expected no retries for an unsafe, non-idempotent method, got %d attempts", attempts)
+	}
+}
+
+func TestRetry_StopsOnContextCancellation(t *testing.T) {
+	alwaysFail := func(ctx context.Context, req string) (string, error) {
+		return "", errors.New("boom")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ep := Retry[string, string](RetryConfig{
+		MaxAttempts: 5,
+		BaseDelay:   10 * time.Millisecond,
+		Idempotent:  true,
+	})(alwaysFail)
+
+	_, err := ep(ctx, "req")
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package endpoint
+
+import (
+	"context"
+	"testing"
+)
+
+// tagMiddleware returns a Middleware that appends name to the request string
+// before invoking next, and to the response string after, so the order in
+// which a chain of them runs can be observed from a single call.
+func tagMiddleware(name string) Middleware[string, string] {
+	return func(next Endpoint[string, string]) Endpoint[string, string] {
+		return func(ctx context.Context, request string) (string, error) {
+			resp, err := next(ctx, request+name)
+			if err != nil {
+				return "", err
+			}
+			return resp + name, nil
+		}
+	}
+}
+
+func TestChain_AppliesOuterFirstThenMiddlewareInOrder(t *testing.T) {
+	base := func(ctx context.Context, request string) (string, error) {
+		return request + "|", nil
+	}
+
+	chained := Chain(tagMiddleware("outer"), tagMiddleware("a"), tagMiddleware("b"), tagMiddleware("c"))(base)
+
+	resp, err := chained(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Chain(outer, a, b, c)(e) == outer(a(b(c(e)))), so the request is
+	// prefixed outer, then a, then b, then c (each middleware appends to the
+	// request on the way in) and the response is suffixed in the reverse
+	// order (each middleware appends to the response on the way out).
+	want := "outerabc|cbaouter"
+	if resp != want {
+		t.Fatalf("expected %q, got %q", want, resp)
+	}
+}
+
+func TestChain_NoMiddlewareIsJustOuter(t *testing.T) {
+	base := func(ctx context.Context, request string) (string, error) {
+		return request, nil
+	}
+
+	chained := Chain[string, string](tagMiddleware("only"))(base)
+
+	resp, err := chained(context.Background(), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resp != "onlyonly" {
+		t.Fatalf("expected %q, got %q", "onlyonly", resp)
+	}
+}
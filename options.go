@@ -20,6 +20,42 @@ func WithClientHooks[T, R any](hooks ClientHooks) ClientOption[T, R] {
 	}
 }
 
+// WithClientBefore adds one or more RequestFunc that are executed, in order,
+// on the outgoing *http.Request before it is sent to the server.
+func WithClientBefore[T, R any](before ...RequestFunc) ClientOption[T, R] {
+	return func(c *Client[T, R]) {
+		c.before = append(c.before, before...)
+	}
+}
+
+// WithClientAfter adds one or more ClientResponseFunc that are executed, in
+// order, on the *http.Response after it is received but before it is decoded.
+func WithClientAfter[T, R any](after ...ClientResponseFunc) ClientOption[T, R] {
+	return func(c *Client[T, R]) {
+		c.after = append(c.after, after...)
+	}
+}
+
+// WithClientMiddleware adds one or more Middleware that wrap the Endpoint
+// returned by Client.Endpoint. Middleware is applied in the order provided,
+// so the first Middleware is the outermost.
+func WithClientMiddleware[T, R any](middleware ...Middleware[T, R]) ClientOption[T, R] {
+	return func(c *Client[T, R]) {
+		c.middleware = append(c.middleware, middleware...)
+	}
+}
+
+// WithBufferedStream controls whether Endpoint cancels its context as soon as
+// it returns. Set it to true when using a streaming DecodeResponseFunc such
+// as DecodeSSEStream or DecodeNDJSONStream, whose returned stream is read
+// after Endpoint has already returned and would otherwise be torn down
+// immediately.
+func WithBufferedStream[T, R any](buffered bool) ClientOption[T, R] {
+	return func(c *Client[T, R]) {
+		c.bufferedStream = buffered
+	}
+}
+
 type ServerOption[T, R any] func(*Server[T, R])
 
 func WithServerHooks[T, R any](hooks ServerHooks) ServerOption[T, R] {
@@ -39,3 +75,29 @@ func WithServerErrorHandler[T, R any](fn ErrorHandler) ServerOption[T, R] {
 		s.errorHandler = fn
 	}
 }
+
+// WithServerBefore adds one or more RequestFunc that are executed, in order,
+// against the incoming *http.Request before it is decoded.
+func WithServerBefore[T, R any](before ...RequestFunc) ServerOption[T, R] {
+	return func(s *Server[T, R]) {
+		s.before = append(s.before, before...)
+	}
+}
+
+// WithServerAfter adds one or more ServerResponseFunc that are executed, in
+// order, against the http.ResponseWriter after the endpoint has run but
+// before the response is encoded.
+func WithServerAfter[T, R any](after ...ServerResponseFunc) ServerOption[T, R] {
+	return func(s *Server[T, R]) {
+		s.after = append(s.after, after...)
+	}
+}
+
+// WithServerMiddleware adds one or more Middleware that wrap the Endpoint
+// invoked by ServeHTTP. Middleware is applied in the order provided, so the
+// first Middleware is the outermost.
+func WithServerMiddleware[T, R any](middleware ...Middleware[T, R]) ServerOption[T, R] {
+	return func(s *Server[T, R]) {
+		s.middleware = append(s.middleware, middleware...)
+	}
+}
@@ -0,0 +1,96 @@
+// Package otel wraps endpoint.Client and endpoint.Server with OpenTelemetry
+// spans, propagating traceparent/tracestate headers so a single trace spans
+// both sides of the call.
+package otel
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	endpoint "github.com/jkratz55/endpoint-go"
+)
+
+// ClientMiddleware returns a Middleware that starts a client span named name
+// around the wrapped Endpoint and records the outcome on it. Pair it with
+// Propagate to carry the span context to the remote service.
+func ClientMiddleware[T, R any](tracer trace.Tracer, name string) endpoint.Middleware[T, R] {
+	return func(next endpoint.Endpoint[T, R]) endpoint.Endpoint[T, R] {
+		return func(ctx context.Context, request T) (R, error) {
+			ctx, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+			defer span.End()
+
+			resp, err := next(ctx, request)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return resp, err
+		}
+	}
+}
+
+// ServerMiddleware returns a Middleware that starts a server span named name
+// around the wrapped Endpoint and records the outcome on it. Pair it with
+// Extract to link the span to the caller's trace.
+func ServerMiddleware[T, R any](tracer trace.Tracer, name string) endpoint.Middleware[T, R] {
+	return func(next endpoint.Endpoint[T, R]) endpoint.Endpoint[T, R] {
+		return func(ctx context.Context, request T) (R, error) {
+			ctx, span := tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindServer))
+			defer span.End()
+
+			resp, err := next(ctx, request)
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return resp, err
+		}
+	}
+}
+
+// Propagate returns an endpoint.RequestFunc that injects the span context
+// carried on ctx into the outgoing request's headers using the global
+// propagator, so traceparent/tracestate reach the remote service. Use it with
+// endpoint.WithClientBefore alongside ClientMiddleware.
+func Propagate() endpoint.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
+		return ctx
+	}
+}
+
+// Extract returns an endpoint.RequestFunc that extracts a remote span context
+// from the incoming request's traceparent/tracestate headers using the global
+// propagator. Use it with endpoint.WithServerBefore, ahead of ServerMiddleware
+// so the server span is linked as a child of the caller's span.
+func Extract() endpoint.RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(r.Header))
+	}
+}
+
+// RecordTraceInfo is an endpoint.ClientHooks.TraceCompleted implementation
+// that adds the timings captured by endpoint.WithTracing as attributes on the
+// span stored in ctx, giving a single trace both span-level timing and the
+// underlying connection's DNS/connect/TLS/first-byte breakdown.
+func RecordTraceInfo(ctx context.Context, info endpoint.TraceInfo) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("http.method", info.Method),
+		attribute.String("http.url", info.URL),
+		attribute.Int("http.status_code", info.StatusCode),
+		attribute.Int64("http.dns_lookup_ms", info.DNSLookup.Milliseconds()),
+		attribute.Int64("http.connect_ms", info.ConnectionTime.Milliseconds()),
+		attribute.Int64("http.tls_handshake_ms", info.TLSHandshake.Milliseconds()),
+		attribute.Int64("http.server_time_ms", info.ServerTime.Milliseconds()),
+		attribute.Int64("http.total_time_ms", info.TotalTime.Milliseconds()),
+		attribute.Bool("http.conn_reused", info.ConnectionReused),
+		attribute.Bool("http.conn_idle", info.ConnectionIdle),
+	)
+}
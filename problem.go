@@ -0,0 +1,189 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// StatusCoder is implemented by errors that want to control the HTTP status
+// code of the response they produce, instead of the default 500 Internal
+// Server Error. ProblemDetailsErrorHandler honours it; the Server's default
+// ErrorHandler does not consult it.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// Headerer is implemented by errors that want to set additional HTTP headers
+// on the response they produce. ProblemDetailsErrorHandler honours it; the
+// Server's default ErrorHandler does not consult it.
+type Headerer interface {
+	Headers() http.Header
+}
+
+// Detailer is implemented by errors that want to control the human-readable
+// detail message of the response they produce, as opposed to err.Error().
+// ProblemDetailsErrorHandler honours it; the Server's default ErrorHandler
+// does not consult it.
+type Detailer interface {
+	Detail() string
+}
+
+// Violator is implemented by errors that carry validation violations, which
+// ProblemDetailsErrorHandler surfaces as a "violations" extension member.
+type Violator interface {
+	Violations() []ValidationViolation
+}
+
+// ProblemDetails is an RFC 7807 "application/problem+json" response body.
+// Extensions is merged into the top-level JSON object alongside the standard
+// members.
+type ProblemDetails struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+}
+
+// MarshalJSON flattens Extensions alongside ProblemDetails' standard members,
+// per RFC 7807's "extension members" rule.
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]any, len(p.Extensions)+5)
+	for k, v := range p.Extensions {
+		fields[k] = v
+	}
+
+	fields["title"] = p.Title
+	fields["status"] = p.Status
+	if p.Type != "" {
+		fields["type"] = p.Type
+	}
+	if p.Detail != "" {
+		fields["detail"] = p.Detail
+	}
+	if p.Instance != "" {
+		fields["instance"] = p.Instance
+	}
+
+	return json.Marshal(fields)
+}
+
+// ProblemDetailsErrorHandler is an ErrorHandler that writes an RFC 7807
+// application/problem+json response. The status code defaults to 500 unless
+// err implements StatusCoder, the detail message defaults to err.Error()
+// unless err implements Detailer, extra headers are copied from err if it
+// implements Headerer, and validation violations are attached as a
+// "violations" extension member if err implements Violator. Instance is
+// populated from ContextKeyRequestURI when present, e.g. via
+// PopulateRequestContext.
+func ProblemDetailsErrorHandler(ctx context.Context, w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var coder StatusCoder
+	if errors.As(err, &coder) {
+		status = coder.StatusCode()
+	}
+
+	detail := err.Error()
+	var detailer Detailer
+	if errors.As(err, &detailer) {
+		detail = detailer.Detail()
+	}
+
+	problem := ProblemDetails{
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instanceFromContext(ctx),
+	}
+
+	var violator Violator
+	if errors.As(err, &violator) {
+		problem.Extensions = map[string]any{"violations": violator.Violations()}
+	}
+
+	var headerer Headerer
+	if errors.As(err, &headerer) {
+		for k, values := range headerer.Headers() {
+			for _, v := range values {
+				w.Header().Add(k, v)
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(problem)
+}
+
+func instanceFromContext(ctx context.Context) string {
+	uri, _ := ctx.Value(ContextKeyRequestURI).(string)
+	return uri
+}
+
+// ErrorEncoderRegistry maps errors to ErrorHandler implementations by type,
+// via errors.As, so callers can register a typed error -> response mapping
+// without writing a single large ErrorHandler switch. Errors that match no
+// registered type fall back to the registry's fallback ErrorHandler.
+type ErrorEncoderRegistry struct {
+	mu       sync.RWMutex
+	entries  []errorEncoderEntry
+	fallback ErrorHandler
+}
+
+type errorEncoderEntry struct {
+	matches func(err error) (any, bool)
+	handle  func(ctx context.Context, w http.ResponseWriter, matched any)
+}
+
+// NewErrorEncoderRegistry creates an ErrorEncoderRegistry that falls back to
+// ProblemDetailsErrorHandler when fallback is nil.
+func NewErrorEncoderRegistry(fallback ErrorHandler) *ErrorEncoderRegistry {
+	if fallback == nil {
+		fallback = ProblemDetailsErrorHandler
+	}
+	return &ErrorEncoderRegistry{fallback: fallback}
+}
+
+// RegisterErrorEncoder registers handle to be invoked for errors matching
+// type E, as determined by errors.As. Entries are tried in the order they
+// were registered, so register more specific error types first.
+func RegisterErrorEncoder[E error](reg *ErrorEncoderRegistry, handle func(ctx context.Context, w http.ResponseWriter, err E)) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	reg.entries = append(reg.entries, errorEncoderEntry{
+		matches: func(err error) (any, bool) {
+			var target E
+			if errors.As(err, &target) {
+				return target, true
+			}
+			return nil, false
+		},
+		handle: func(ctx context.Context, w http.ResponseWriter, matched any) {
+			handle(ctx, w, matched.(E))
+		},
+	})
+}
+
+// ErrorHandler returns an ErrorHandler that dispatches to the registered
+// entries, falling back to reg's fallback handler if none match.
+func (reg *ErrorEncoderRegistry) ErrorHandler() ErrorHandler {
+	return func(ctx context.Context, w http.ResponseWriter, err error) {
+		reg.mu.RLock()
+		entries := reg.entries
+		reg.mu.RUnlock()
+
+		for _, entry := range entries {
+			if matched, ok := entry.matches(err); ok {
+				entry.handle(ctx, w, matched)
+				return
+			}
+		}
+
+		reg.fallback(ctx, w, err)
+	}
+}
@@ -0,0 +1,141 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// richError implements StatusCoder, Detailer and Headerer so a single test
+// can exercise all three of ProblemDetailsErrorHandler's optional interface
+// dispatches at once.
+type richError struct {
+	status  int
+	detail  string
+	headers http.Header
+}
+
+func (e richError) Error() string        { return "rich error" }
+func (e richError) StatusCode() int      { return e.status }
+func (e richError) Detail() string       { return e.detail }
+func (e richError) Headers() http.Header { return e.headers }
+
+func TestProblemDetailsErrorHandler_DispatchesStatusCoderDetailerHeaderer(t *testing.T) {
+	err := richError{
+		status:  http.StatusConflict,
+		detail:  "widget already exists",
+		headers: http.Header{"Retry-After": []string{"5"}},
+	}
+
+	rec := httptest.NewRecorder()
+	ProblemDetailsErrorHandler(context.Background(), rec, err)
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("expected StatusCoder to set the response status, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") != "5" {
+		t.Fatalf("expected Headerer's headers to be copied onto the response")
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Fatalf("expected application/problem+json, got %q", ct)
+	}
+
+	var problem map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if problem["detail"] != "widget already exists" {
+		t.Fatalf("expected Detailer's message to be used as detail, got %v", problem["detail"])
+	}
+	if problem["title"] != http.StatusText(http.StatusConflict) {
+		t.Fatalf("expected title to be derived from the status code, got %v", problem["title"])
+	}
+}
+
+func TestProblemDetailsErrorHandler_PlainErrorDefaultsTo500(t *testing.T) {
+	rec := httptest.NewRecorder()
+	ProblemDetailsErrorHandler(context.Background(), rec, errors.New("boom"))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("expected a plain error to default to 500, got %d", rec.Code)
+	}
+
+	var problem map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if problem["detail"] != "boom" {
+		t.Fatalf("expected detail to fall back to err.Error(), got %v", problem["detail"])
+	}
+}
+
+type notFoundError struct{ resource string }
+
+func (e notFoundError) Error() string { return "not found: " + e.resource }
+
+type conflictError struct{ resource string }
+
+func (e conflictError) Error() string { return "conflict: " + e.resource }
+
+func TestErrorEncoderRegistry_DispatchesByRegisteredType(t *testing.T) {
+	reg := NewErrorEncoderRegistry(nil)
+
+	RegisterErrorEncoder(reg, func(ctx context.Context, w http.ResponseWriter, err notFoundError) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(err.resource))
+	})
+	RegisterErrorEncoder(reg, func(ctx context.Context, w http.ResponseWriter, err conflictError) {
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(err.resource))
+	})
+
+	handler := reg.ErrorHandler()
+
+	rec := httptest.NewRecorder()
+	handler(context.Background(), rec, notFoundError{resource: "widget"})
+	if rec.Code != http.StatusNotFound || rec.Body.String() != "widget" {
+		t.Fatalf("expected the notFoundError entry to handle the error, got %d %q", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	handler(context.Background(), rec, conflictError{resource: "gadget"})
+	if rec.Code != http.StatusConflict || rec.Body.String() != "gadget" {
+		t.Fatalf("expected the conflictError entry to handle the error, got %d %q", rec.Code, rec.Body.String())
+	}
+}
+
+func TestErrorEncoderRegistry_FallsBackToProblemDetailsByDefault(t *testing.T) {
+	reg := NewErrorEncoderRegistry(nil)
+	handler := reg.ErrorHandler()
+
+	rec := httptest.NewRecorder()
+	handler(context.Background(), rec, errors.New("unregistered"))
+
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Fatalf("expected an unmatched error to fall back to ProblemDetailsErrorHandler, got Content-Type %q", ct)
+	}
+}
+
+func TestErrorEncoderRegistry_FallsBackToProvidedHandler(t *testing.T) {
+	var fallbackCalled bool
+	fallback := func(ctx context.Context, w http.ResponseWriter, err error) {
+		fallbackCalled = true
+		w.WriteHeader(http.StatusTeapot)
+	}
+
+	reg := NewErrorEncoderRegistry(fallback)
+	handler := reg.ErrorHandler()
+
+	rec := httptest.NewRecorder()
+	handler(context.Background(), rec, errors.New("unregistered"))
+
+	if !fallbackCalled {
+		t.Fatalf("expected the custom fallback to be invoked")
+	}
+	if rec.Code != http.StatusTeapot {
+		t.Fatalf("expected the custom fallback's status to be used, got %d", rec.Code)
+	}
+}
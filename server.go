@@ -48,6 +48,9 @@ type Server[T, R any] struct {
 	validator    Validator[T]
 	errorHandler ErrorHandler
 	hooks        ServerHooks
+	before       []RequestFunc
+	after        []ServerResponseFunc
+	middleware   []Middleware[T, R]
 }
 
 func NewServer[T, R any](
@@ -67,11 +70,16 @@ func NewServer[T, R any](
 		opt(s)
 	}
 
+	for i := len(s.middleware) - 1; i >= 0; i-- {
+		s.endpoint = s.middleware[i](s.endpoint)
+	}
+
 	return s
 }
 
 func (s *Server[T, R]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	ctx = context.WithValue(ctx, acceptContextKey{}, r.Header.Get("Accept"))
 
 	if s.hooks.Finalizer != nil {
 		rw := &responseWriter{
@@ -89,6 +97,10 @@ func (s *Server[T, R]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		ctx = s.hooks.BeforeDecodeRequest(ctx, r)
 	}
 
+	for _, before := range s.before {
+		ctx = before(ctx, r)
+	}
+
 	req, err := s.decoder(ctx, r)
 	if err != nil {
 		decodeErr := fmt.Errorf("%w: %w", ErrDecodeRequest, err)
@@ -116,15 +128,7 @@ func (s *Server[T, R]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		}
 
 		if !ok {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusBadRequest)
-			_ = json.NewEncoder(w).Encode(ErrorResponse{
-				Status:    http.StatusBadRequest,
-				Message:   "Bad Request",
-				Path:      r.URL.Path,
-				Details:   violations,
-				Timestamp: time.Now().Unix(),
-			})
+			s.errorHandler(ctx, w, validationError{path: r.URL.Path, violations: violations})
 			return
 		}
 	}
@@ -139,6 +143,10 @@ func (s *Server[T, R]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		s.hooks.AfterEndpoint(ctx, w)
 	}
 
+	for _, after := range s.after {
+		ctx = after(ctx, w)
+	}
+
 	if err := s.encoder(ctx, w, response); err != nil {
 		s.errorHandler(ctx, w, fmt.Errorf("%w: %w", ErrEncodeResponse, err))
 		return
@@ -147,7 +155,47 @@ func (s *Server[T, R]) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 func defaultServerErrorHandler() ErrorHandler {
 	return func(ctx context.Context, w http.ResponseWriter, err error) {
+		var verr validationError
+		var violator Violator
 		switch {
+		case errors.As(err, &verr):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{
+				Status:    http.StatusBadRequest,
+				Message:   "Bad Request",
+				Path:      verr.path,
+				Details:   verr.violations,
+				Timestamp: time.Now().Unix(),
+			})
+		case errors.As(err, &violator):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{
+				Status:    http.StatusBadRequest,
+				Message:   "Bad Request",
+				Path:      instanceFromContext(ctx),
+				Details:   violator.Violations(),
+				Timestamp: time.Now().Unix(),
+			})
+		case errors.Is(err, ErrUnsupportedMediaType):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{
+				Status:    http.StatusUnsupportedMediaType,
+				Message:   "Unsupported Media Type",
+				Details:   err.Error(),
+				Timestamp: time.Now().Unix(),
+			})
+		case errors.Is(err, ErrNotAcceptable):
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotAcceptable)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{
+				Status:    http.StatusNotAcceptable,
+				Message:   "Not Acceptable",
+				Details:   err.Error(),
+				Timestamp: time.Now().Unix(),
+			})
 		case errors.Is(err, ErrDecodeRequest):
 			w.Header().Set("Content-Type", "application/json")
 			w.WriteHeader(http.StatusBadRequest)
@@ -0,0 +1,49 @@
+package endpoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestServer_ValidationFailureUsesErrorHandler(t *testing.T) {
+	ep := func(ctx context.Context, req string) (string, error) {
+		t.Fatalf("endpoint should not be invoked when validation fails")
+		return "", nil
+	}
+
+	validator := func(req any) (bool, []ValidationViolation) {
+		return false, []ValidationViolation{{Field: "name", Message: "required"}}
+	}
+
+	server := NewServer[string, string](
+		ep,
+		DecodeRequest[string](JSONCodec{}),
+		EncodeResponse[string](JSONCodec{}),
+		WithServerValidator[string](validator),
+		WithServerErrorHandler[string, string](ProblemDetailsErrorHandler),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/widgets", strings.NewReader(`"ignored"`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	server.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+		t.Fatalf("expected validation failures to go through the configured ErrorHandler, got Content-Type %q", ct)
+	}
+
+	var problem map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &problem); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if _, ok := problem["violations"]; !ok {
+		t.Fatalf("expected violations extension member, got %v", problem)
+	}
+}
@@ -0,0 +1,209 @@
+package endpoint
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// StreamResult pairs a single decoded stream element with any error
+// encountered while reading or decoding it. A non-nil Err marks the end of a
+// usable stream; the channel is closed immediately afterward.
+type StreamResult[R any] struct {
+	Value R
+	Err   error
+}
+
+// DecodeSSEStream returns a DecodeResponseFunc that reads a text/event-stream
+// response and decodes each event's data field with decode, delivering
+// results on the returned channel as they arrive. The channel, and the
+// response body, are closed once the stream ends, ctx is cancelled, or a read
+// error occurs. Pair it with WithBufferedStream(true) so the Client does not
+// cancel ctx before the stream has been fully drained.
+func DecodeSSEStream[R any](decode func(data []byte) (R, error)) DecodeResponseFunc[<-chan StreamResult[R]] {
+	return func(ctx context.Context, resp *http.Response) (<-chan StreamResult[R], error) {
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			return nil, HttpError{Status: resp.StatusCode, Header: resp.Header, Body: body}
+		}
+
+		out := make(chan StreamResult[R])
+
+		go func() {
+			defer close(out)
+			defer resp.Body.Close()
+
+			var dataLines []string
+			flush := func() bool {
+				if len(dataLines) == 0 {
+					return true
+				}
+				data := []byte(strings.Join(dataLines, "\n"))
+				dataLines = dataLines[:0]
+				value, err := decode(data)
+				select {
+				case out <- StreamResult[R]{Value: value, Err: err}:
+					return err == nil
+				case <-ctx.Done():
+					return false
+				}
+			}
+
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				line := scanner.Text()
+				switch {
+				case line == "":
+					if !flush() {
+						return
+					}
+				case strings.HasPrefix(line, "data:"):
+					dataLines = append(dataLines, strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+				default:
+					// id:/event:/retry:/comment lines are not needed by decode
+					// and are ignored.
+				}
+			}
+			if !flush() {
+				return
+			}
+
+			if err := scanner.Err(); err != nil {
+				select {
+				case out <- StreamResult[R]{Err: err}:
+				case <-ctx.Done():
+				}
+			}
+		}()
+
+		return out, nil
+	}
+}
+
+// DecodeNDJSONStream returns a DecodeResponseFunc that reads a
+// newline-delimited JSON response, decoding each document into R and
+// delivering results on the returned channel as they arrive. The channel, and
+// the response body, are closed once the stream ends, ctx is cancelled, or a
+// decode error occurs. Pair it with WithBufferedStream(true) so the Client
+// does not cancel ctx before the stream has been fully drained.
+func DecodeNDJSONStream[R any]() DecodeResponseFunc[<-chan StreamResult[R]] {
+	return func(ctx context.Context, resp *http.Response) (<-chan StreamResult[R], error) {
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			defer resp.Body.Close()
+			body, _ := io.ReadAll(resp.Body)
+			return nil, HttpError{Status: resp.StatusCode, Header: resp.Header, Body: body}
+		}
+
+		out := make(chan StreamResult[R])
+
+		go func() {
+			defer close(out)
+			defer resp.Body.Close()
+
+			decoder := json.NewDecoder(resp.Body)
+			for decoder.More() {
+				select {
+				case <-ctx.Done():
+					return
+				default:
+				}
+
+				var value R
+				err := decoder.Decode(&value)
+				select {
+				case out <- StreamResult[R]{Value: value, Err: err}:
+				case <-ctx.Done():
+					return
+				}
+				if err != nil {
+					return
+				}
+			}
+		}()
+
+		return out, nil
+	}
+}
+
+// EncodeSSEResponse returns an EncodeResponseFunc that writes each value
+// received from data as a Server-Sent Event, encoding it with encode and
+// flushing the responseWriter after every event. The endpoint should close
+// data once there is nothing left to send.
+func EncodeSSEResponse[R any](encode func(v R) ([]byte, error)) EncodeResponseFunc[<-chan R] {
+	return func(ctx context.Context, w http.ResponseWriter, data <-chan R) error {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+
+		flusher, _ := w.(http.Flusher)
+
+		for {
+			select {
+			case v, ok := <-data:
+				if !ok {
+					return nil
+				}
+				payload, err := encode(v)
+				if err != nil {
+					return err
+				}
+				for _, line := range strings.Split(string(payload), "\n") {
+					if _, err := fmt.Fprintf(w, "data: %s\n", line); err != nil {
+						return err
+					}
+				}
+				if _, err := io.WriteString(w, "\n"); err != nil {
+					return err
+				}
+				if flusher != nil {
+					flusher.Flush()
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
+
+// EncodeNDJSONResponse returns an EncodeResponseFunc that writes each value
+// received from data as a newline-delimited JSON document, flushing the
+// responseWriter after every line. The endpoint should close data once there
+// is nothing left to send.
+func EncodeNDJSONResponse[R any]() EncodeResponseFunc[<-chan R] {
+	return func(ctx context.Context, w http.ResponseWriter, data <-chan R) error {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.WriteHeader(http.StatusOK)
+
+		flusher, _ := w.(http.Flusher)
+		encoder := json.NewEncoder(w)
+
+		for {
+			select {
+			case v, ok := <-data:
+				if !ok {
+					return nil
+				}
+				if err := encoder.Encode(v); err != nil {
+					return err
+				}
+				if flusher != nil {
+					flusher.Flush()
+				}
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDecodeSSEStream_MultiEventMultiLine(t *testing.T) {
+	body := "data: line one\n" +
+		"data: line two\n" +
+		"\n" +
+		"id: 42\n" +
+		"data: second event\n" +
+		"\n"
+
+	decode := func(data []byte) (string, error) {
+		return string(data), nil
+	}
+
+	resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+	ch, err := DecodeSSEStream[string](decode)(context.Background(), resp)
+	if err != nil {
+		t.Fatalf("DecodeSSEStream: %v", err)
+	}
+
+	var got []string
+	for result := range ch {
+		if result.Err != nil {
+			t.Fatalf("unexpected stream error: %v", result.Err)
+		}
+		got = append(got, result.Value)
+	}
+
+	want := []string{"line one\nline two", "second event"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestDecodeSSEStream_StopsOnContextCancellation(t *testing.T) {
+	body := "data: first\n\ndata: second\n\ndata: third\n\n"
+	decode := func(data []byte) (string, error) {
+		return string(data), nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+	ch, err := DecodeSSEStream[string](decode)(ctx, resp)
+	if err != nil {
+		t.Fatalf("DecodeSSEStream: %v", err)
+	}
+
+	first, ok := <-ch
+	if !ok || first.Err != nil || first.Value != "first" {
+		t.Fatalf("expected the first event, got %+v, ok=%v", first, ok)
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			// A second event may still win the race against cancellation;
+			// draining once more should then observe the channel close.
+			if _, ok := <-ch; ok {
+				t.Fatalf("expected the stream to stop after context cancellation")
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the stream to close after cancellation")
+	}
+}
+
+func TestDecodeNDJSONStream_MalformedTrailingJSON(t *testing.T) {
+	body := `{"name":"a"}` + "\n" + `{"name":"b"}` + "\n" + `{not-json`
+
+	resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+	ch, err := DecodeNDJSONStream[map[string]string]()(context.Background(), resp)
+	if err != nil {
+		t.Fatalf("DecodeNDJSONStream: %v", err)
+	}
+
+	var values []map[string]string
+	var lastErr error
+	for result := range ch {
+		if result.Err != nil {
+			lastErr = result.Err
+			continue
+		}
+		values = append(values, result.Value)
+	}
+
+	if len(values) != 2 {
+		t.Fatalf("expected 2 successfully decoded documents before the malformed one, got %d (%v)", len(values), values)
+	}
+	if values[0]["name"] != "a" || values[1]["name"] != "b" {
+		t.Fatalf("unexpected decoded values: %v", values)
+	}
+	if lastErr == nil {
+		t.Fatalf("expected a decode error for the malformed trailing document")
+	}
+}
+
+func TestDecodeNDJSONStream_StopsOnContextCancellation(t *testing.T) {
+	body := `{"name":"a"}` + "\n" + `{"name":"b"}` + "\n" + `{"name":"c"}`
+
+	ctx, cancel := context.WithCancel(context.Background())
+	resp := &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(body))}
+	ch, err := DecodeNDJSONStream[map[string]string]()(ctx, resp)
+	if err != nil {
+		t.Fatalf("DecodeNDJSONStream: %v", err)
+	}
+
+	first, ok := <-ch
+	if !ok || first.Err != nil || first.Value["name"] != "a" {
+		t.Fatalf("expected the first document, got %+v, ok=%v", first, ok)
+	}
+
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			if _, ok := <-ch; ok {
+				t.Fatalf("expected the stream to stop after context cancellation")
+			}
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("timed out waiting for the stream to close after cancellation")
+	}
+}
+
+func TestDecodeSSEStream_NonSuccessStatusReturnsHttpError(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusInternalServerError,
+		Body:       io.NopCloser(strings.NewReader("boom")),
+	}
+
+	_, err := DecodeSSEStream[string](func(data []byte) (string, error) { return string(data), nil })(context.Background(), resp)
+	if err == nil {
+		t.Fatalf("expected an error for a non-2xx response")
+	}
+
+	var httpErr HttpError
+	if !errors.As(err, &httpErr) || httpErr.Status != http.StatusInternalServerError {
+		t.Fatalf("expected an HttpError with status 500, got %v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package endpoint
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+)
+
+type traceContextKey struct{}
+
+// traceState accumulates timings from an in-flight httptrace.ClientTrace. It
+// is safe for concurrent use since httptrace callbacks are not guaranteed to
+// run on the same goroutine that issued the request.
+type traceState struct {
+	mu    sync.Mutex
+	start time.Time
+	info  TraceInfo
+
+	// dnsStart, connStart, tlsStart and wroteRequest are written by one
+	// httptrace callback and read by another (e.g. DNSStart/DNSDone).
+	// net/http/httptrace does not guarantee these fire on the same goroutine,
+	// or even that ConnectStart/ConnectDone don't fire concurrently for
+	// multiple dial attempts, so they are guarded by mu like every other
+	// field here.
+	dnsStart     time.Time
+	connStart    time.Time
+	tlsStart     time.Time
+	wroteRequest time.Time
+}
+
+// WithTracing returns a ClientOption that attaches an httptrace.ClientTrace to
+// the outgoing request, capturing DNS lookup, connect, TLS handshake and
+// time-to-first-byte timings into a TraceInfo. Once the response has been
+// received, ClientHooks.TraceCompleted, if set, is invoked with the populated
+// TraceInfo.
+func WithTracing[T, R any]() ClientOption[T, R] {
+	return func(c *Client[T, R]) {
+		c.before = append(c.before, startClientTrace)
+		c.after = append(c.after, func(ctx context.Context, resp *http.Response) context.Context {
+			if c.hooks.TraceCompleted != nil {
+				c.hooks.TraceCompleted(ctx, finishClientTrace(ctx, resp))
+			}
+			return ctx
+		})
+	}
+}
+
+func startClientTrace(ctx context.Context, r *http.Request) context.Context {
+	state := &traceState{
+		start: time.Now(),
+		info:  TraceInfo{Method: r.Method, URL: r.URL.String()},
+	}
+
+	trace := &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) {
+			state.mu.Lock()
+			state.dnsStart = time.Now()
+			state.mu.Unlock()
+		},
+		DNSDone: func(httptrace.DNSDoneInfo) {
+			state.mu.Lock()
+			state.info.DNSLookup = time.Since(state.dnsStart)
+			state.mu.Unlock()
+		},
+		ConnectStart: func(string, string) {
+			state.mu.Lock()
+			state.connStart = time.Now()
+			state.mu.Unlock()
+		},
+		ConnectDone: func(string, string, error) {
+			state.mu.Lock()
+			state.info.ConnectionTime = time.Since(state.connStart)
+			state.mu.Unlock()
+		},
+		TLSHandshakeStart: func() {
+			state.mu.Lock()
+			state.tlsStart = time.Now()
+			state.mu.Unlock()
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) {
+			state.mu.Lock()
+			state.info.TLSHandshake = time.Since(state.tlsStart)
+			state.mu.Unlock()
+		},
+		GotConn: func(info httptrace.GotConnInfo) {
+			state.mu.Lock()
+			state.info.ConnectionReused = info.Reused
+			state.info.ConnectionIdle = info.WasIdle
+			state.mu.Unlock()
+		},
+		WroteRequest: func(httptrace.WroteRequestInfo) {
+			state.mu.Lock()
+			state.wroteRequest = time.Now()
+			state.mu.Unlock()
+		},
+		GotFirstResponseByte: func() {
+			state.mu.Lock()
+			// ServerTime is documented as the time from when the request was
+			// sent until the first response byte arrives. Measuring from
+			// state.start would double-count DNS/connect/TLS setup time,
+			// which is already reported separately.
+			state.info.ServerTime = time.Since(state.wroteRequest)
+			state.mu.Unlock()
+		},
+	}
+
+	ctx = context.WithValue(ctx, traceContextKey{}, state)
+	return httptrace.WithClientTrace(ctx, trace)
+}
+
+func finishClientTrace(ctx context.Context, resp *http.Response) TraceInfo {
+	state, ok := ctx.Value(traceContextKey{}).(*traceState)
+	if !ok {
+		return TraceInfo{}
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	state.info.TotalTime = time.Since(state.start)
+	if resp != nil {
+		state.info.StatusCode = resp.StatusCode
+	}
+	return state.info
+}
@@ -0,0 +1,47 @@
+package endpoint
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptrace"
+	"testing"
+	"time"
+)
+
+func TestStartClientTrace_ServerTimeExcludesConnectionSetup(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "http://example.invalid/widgets", nil)
+	if err != nil {
+		t.Fatalf("NewRequest: %v", err)
+	}
+
+	ctx := startClientTrace(context.Background(), req)
+	trace := httptrace.ContextClientTrace(ctx)
+	if trace == nil {
+		t.Fatalf("expected a ClientTrace to be attached to the context")
+	}
+
+	// Simulate a slow DNS lookup and connect, followed by a fast round trip.
+	trace.DNSStart(httptrace.DNSStartInfo{})
+	time.Sleep(20 * time.Millisecond)
+	trace.DNSDone(httptrace.DNSDoneInfo{})
+
+	trace.ConnectStart("tcp", "example.invalid:80")
+	time.Sleep(20 * time.Millisecond)
+	trace.ConnectDone("tcp", "example.invalid:80", nil)
+
+	trace.WroteRequest(httptrace.WroteRequestInfo{})
+	time.Sleep(5 * time.Millisecond)
+	trace.GotFirstResponseByte()
+
+	info := finishClientTrace(ctx, &http.Response{StatusCode: http.StatusOK})
+
+	if info.DNSLookup < 20*time.Millisecond {
+		t.Fatalf("expected DNSLookup to reflect the simulated delay, got %v", info.DNSLookup)
+	}
+	if info.ConnectionTime < 20*time.Millisecond {
+		t.Fatalf("expected ConnectionTime to reflect the simulated delay, got %v", info.ConnectionTime)
+	}
+	if info.ServerTime >= 20*time.Millisecond {
+		t.Fatalf("expected ServerTime to exclude DNS/connect setup, got %v", info.ServerTime)
+	}
+}
@@ -0,0 +1,80 @@
+package endpoint
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// RequestFunc is executed on an HTTP request before it is sent by a Client, or
+// after it is received but before it is decoded by a Server. It may mutate the
+// request and/or return a derived context, and is the primary extension point
+// for cross-cutting concerns such as auth headers and trace propagation.
+type RequestFunc func(ctx context.Context, r *http.Request) context.Context
+
+// ClientResponseFunc is executed on the HTTP response received by a Client,
+// after ClientHooks.ResponseReceived but before the DecodeResponseFunc is
+// invoked.
+type ClientResponseFunc func(ctx context.Context, r *http.Response) context.Context
+
+// ServerResponseFunc is executed by a Server against the http.ResponseWriter
+// after the endpoint has run but before the response is encoded and written
+// to the client, e.g. to set response headers.
+type ServerResponseFunc func(ctx context.Context, w http.ResponseWriter) context.Context
+
+type contextKey int
+
+const (
+	// ContextKeyRequestMethod is the context key under which PopulateRequestContext
+	// stores the request's HTTP method.
+	ContextKeyRequestMethod contextKey = iota
+
+	// ContextKeyRequestURI is the context key under which PopulateRequestContext
+	// stores the request's RequestURI.
+	ContextKeyRequestURI
+
+	// ContextKeyRequestHeader is the context key under which PopulateRequestContext
+	// stores the request's headers.
+	ContextKeyRequestHeader
+)
+
+// SetRequestHeader returns a RequestFunc that sets the header on the outgoing
+// HTTP request.
+func SetRequestHeader(key, value string) RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		r.Header.Set(key, value)
+		return ctx
+	}
+}
+
+// SetResponseHeader returns a ServerResponseFunc that sets the header on the
+// HTTP response before it is written to the client.
+func SetResponseHeader(key, value string) ServerResponseFunc {
+	return func(ctx context.Context, w http.ResponseWriter) context.Context {
+		w.Header().Set(key, value)
+		return ctx
+	}
+}
+
+// PopulateRequestContext is a RequestFunc that copies the request's method,
+// URI and headers into the context so they can be read further down the
+// pipeline without holding onto the *http.Request itself.
+func PopulateRequestContext(ctx context.Context, r *http.Request) context.Context {
+	ctx = context.WithValue(ctx, ContextKeyRequestMethod, r.Method)
+	ctx = context.WithValue(ctx, ContextKeyRequestURI, r.RequestURI)
+	ctx = context.WithValue(ctx, ContextKeyRequestHeader, r.Header)
+	return ctx
+}
+
+// CopyURL returns a RequestFunc that replaces the outgoing request's URL with
+// a copy of base, preserving the original request's Path, RawPath and
+// RawQuery. It is useful when the destination host is only resolved once the
+// Before pipeline runs, e.g. after looking it up in a service registry.
+func CopyURL(base *url.URL) RequestFunc {
+	return func(ctx context.Context, r *http.Request) context.Context {
+		u := *base
+		u.Path, u.RawPath, u.RawQuery = r.URL.Path, r.URL.RawPath, r.URL.RawQuery
+		r.URL = &u
+		return ctx
+	}
+}
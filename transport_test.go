@@ -0,0 +1,86 @@
+package endpoint
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestSetRequestHeader(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/widgets", nil)
+
+	ctx := SetRequestHeader("X-Api-Key", "secret")(context.Background(), req)
+
+	if ctx != context.Background() {
+		t.Fatalf("expected SetRequestHeader to return ctx unchanged")
+	}
+	if got := req.Header.Get("X-Api-Key"); got != "secret" {
+		t.Fatalf("expected header to be set, got %q", got)
+	}
+}
+
+func TestSetResponseHeader(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	SetResponseHeader("X-Request-Id", "abc-123")(context.Background(), rec)
+
+	if got := rec.Header().Get("X-Request-Id"); got != "abc-123" {
+		t.Fatalf("expected header to be set, got %q", got)
+	}
+}
+
+func TestPopulateRequestContext(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/widgets?x=1", nil)
+	req.RequestURI = "/widgets?x=1"
+	req.Header.Set("X-Trace-Id", "trace-1")
+
+	ctx := PopulateRequestContext(context.Background(), req)
+
+	if got, _ := ctx.Value(ContextKeyRequestMethod).(string); got != http.MethodPost {
+		t.Fatalf("expected method %q, got %q", http.MethodPost, got)
+	}
+	if got, _ := ctx.Value(ContextKeyRequestURI).(string); got != "/widgets?x=1" {
+		t.Fatalf("expected URI %q, got %q", "/widgets?x=1", got)
+	}
+	header, _ := ctx.Value(ContextKeyRequestHeader).(http.Header)
+	if header.Get("X-Trace-Id") != "trace-1" {
+		t.Fatalf("expected header to be populated into the context, got %v", header)
+	}
+}
+
+func TestCopyURL_PreservesPathAndQueryFromOriginalRequest(t *testing.T) {
+	base, err := url.Parse("https://internal.example.com:8443")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://original.example.com/widgets/1?limit=10", nil)
+
+	CopyURL(base)(context.Background(), req)
+
+	if req.URL.Scheme != "https" || req.URL.Host != "internal.example.com:8443" {
+		t.Fatalf("expected the base scheme/host to be used, got %s", req.URL)
+	}
+	if req.URL.Path != "/widgets/1" {
+		t.Fatalf("expected the original path to be preserved, got %s", req.URL.Path)
+	}
+	if req.URL.RawQuery != "limit=10" {
+		t.Fatalf("expected the original query to be preserved, got %s", req.URL.RawQuery)
+	}
+}
+
+func TestCopyURL_DoesNotMutateBase(t *testing.T) {
+	base, err := url.Parse("https://internal.example.com")
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "http://original.example.com/widgets/1", nil)
+	CopyURL(base)(context.Background(), req)
+
+	if base.Path != "" {
+		t.Fatalf("expected base URL to be left unmodified, got path %q", base.Path)
+	}
+}
@@ -1,5 +1,7 @@
 package endpoint
 
+import "net/http"
+
 // Validator is a function hook that is invoked after the request is decoded but
 // before the endpoint is invoked. The validator is responsible for validating the
 // request to ensure it is valid before the endpoint is invoked.
@@ -11,3 +13,26 @@ type ValidationViolation struct {
 	Field   string `json:"field"`
 	Message string `json:"message"`
 }
+
+// validationError is returned to the configured ErrorHandler when Server's
+// Validator rejects a request. It implements StatusCoder so handlers such as
+// ProblemDetailsErrorHandler respond with 400 Bad Request, and Violator so the
+// rejected ValidationViolations are available to whichever response format
+// the handler produces. The default ErrorHandler matches validationError by
+// type and also responds with 400 Bad Request.
+type validationError struct {
+	path       string
+	violations []ValidationViolation
+}
+
+func (e validationError) Error() string {
+	return "request failed validation"
+}
+
+func (e validationError) StatusCode() int {
+	return http.StatusBadRequest
+}
+
+func (e validationError) Violations() []ValidationViolation {
+	return e.violations
+}